@@ -0,0 +1,40 @@
+package types
+
+import "github.com/NilFoundation/nil/nil/common"
+
+// TransactionMaxAccessListSize caps the number of AccessTuple entries a
+// transaction may declare, analogous to TransactionMaxTokenSize.
+const TransactionMaxAccessListSize = 256
+
+// TransactionMaxAccessListStorageKeys caps the total number of storage keys
+// across all AccessTuple entries, since a single address could otherwise
+// smuggle an unbounded number of keys past TransactionMaxAccessListSize.
+const TransactionMaxAccessListStorageKeys = 4096
+
+// AccessList lets a transaction pre-declare the storage it plans to touch,
+// in the shape of an EIP-2930 access list. It is carried in the transaction,
+// included in SigningHash and size-checked by VerifyFlags; nothing in this
+// package marks the listed slots warm or changes what accessing them costs.
+type AccessList []AccessTuple
+
+// AccessTuple pairs an address with the storage keys within it that a
+// transaction pre-declares.
+type AccessTuple struct {
+	Address     Address       `json:"address"`
+	StorageKeys []common.Hash `json:"storageKeys"`
+}
+
+// StorageKeyCount returns the total number of storage keys across the whole
+// list, which is what size limits should count against.
+func (al AccessList) StorageKeyCount() int {
+	n := 0
+	for _, t := range al {
+		n += len(t.StorageKeys)
+	}
+	return n
+}
+
+// Note: the wallet-facing "what access list would this transaction
+// generate" estimation endpoint belongs to the node's RPC package (it needs
+// to dry-run the transaction against current state), which isn't part of
+// this module; it should build on AccessList/AccessTuple defined here.
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common"
+)
+
+func TestAccessListStorageKeyCount(t *testing.T) {
+	t.Parallel()
+
+	al := AccessList{
+		{Address: Address{1}, StorageKeys: []common.Hash{{1}, {2}}},
+		{Address: Address{2}, StorageKeys: []common.Hash{{3}}},
+	}
+	if got := al.StorageKeyCount(); got != 3 {
+		t.Fatalf("expected 3 storage keys, got %d", got)
+	}
+	if got := AccessList(nil).StorageKeyCount(); got != 0 {
+		t.Fatalf("expected 0 storage keys for an empty access list, got %d", got)
+	}
+}
+
+func TestTransactionVerifyFlagsRejectsOversizedAccessList(t *testing.T) {
+	t.Parallel()
+
+	tx := NewEmptyTransaction()
+	tx.AccessList = make(AccessList, TransactionMaxAccessListSize+1)
+	if err := tx.VerifyFlags(); err == nil {
+		t.Fatal("expected an error for an access list exceeding TransactionMaxAccessListSize")
+	}
+}
+
+func TestTransactionVerifyFlagsRejectsTooManyStorageKeys(t *testing.T) {
+	t.Parallel()
+
+	tx := NewEmptyTransaction()
+	tx.AccessList = AccessList{
+		{Address: Address{1}, StorageKeys: make([]common.Hash, TransactionMaxAccessListStorageKeys+1)},
+	}
+	if err := tx.VerifyFlags(); err == nil {
+		t.Fatal("expected an error for an access list exceeding TransactionMaxAccessListStorageKeys")
+	}
+}
+
+func TestTransactionVerifyFlagsAcceptsAccessListWithinLimits(t *testing.T) {
+	t.Parallel()
+
+	tx := NewEmptyTransaction()
+	tx.AccessList = AccessList{
+		{Address: Address{1}, StorageKeys: []common.Hash{{1}}},
+	}
+	if err := tx.VerifyFlags(); err != nil {
+		t.Fatalf("VerifyFlags: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func init() {
+	RegisterTransactionType(SetCodeTransactionKind, 0x04)
+}
+
+// DelegationDesignationPrefix is prepended to the delegate address to form
+// the code a signer's account is set to after a valid Authorization is
+// applied, per EIP-7702.
+var DelegationDesignationPrefix = [3]byte{0xef, 0x01, 0x00}
+
+// Authorization lets an EOA delegate its code to another account. It is
+// signed independently of the transaction that carries it, by the account
+// being delegated, so SetCodeTransactionKind can batch authorizations from
+// several signers into a single transaction.
+//
+// ApplyAuthorization applies one entry; an invalid authorization is
+// skipped, it does not fail the transaction. Nothing in this package calls
+// ApplyAuthorization while executing a transaction.
+type Authorization struct {
+	ChainId ChainId     `json:"chainId"`
+	Address Address     `json:"address"`
+	Nonce   Seqno       `json:"nonce"`
+	V       uint8       `json:"v"`
+	R       common.Hash `json:"r"`
+	S       common.Hash `json:"s"`
+}
+
+// SigningHash returns the hash an account signs to authorize delegating its
+// code to Address at Nonce.
+func (a *Authorization) SigningHash() (common.Hash, error) {
+	return common.Keccak(&struct {
+		ChainId ChainId
+		Address Address
+		Nonce   Seqno
+	}{a.ChainId, a.Address, a.Nonce})
+}
+
+// Recover recovers the address that signed this authorization. Callers
+// still need to check ChainId and Nonce against current state before
+// applying it; a recovered address alone does not make an authorization
+// valid.
+func (a *Authorization) Recover() (Address, error) {
+	if a.V > 1 {
+		return Address{}, errors.New("invalid authorization signature: v must be 0 or 1")
+	}
+	hash, err := a.SigningHash()
+	if err != nil {
+		return Address{}, err
+	}
+	sig := make([]byte, 65)
+	copy(sig[0:32], a.R.Bytes())
+	copy(sig[32:64], a.S.Bytes())
+	sig[64] = a.V
+	pub, err := crypto.SigToPub(hash.Bytes(), sig)
+	if err != nil {
+		return Address{}, err
+	}
+	return BytesToAddress(crypto.PubkeyToAddress(*pub).Bytes()), nil
+}
+
+func (a Authorization) MarshalNil() ([]byte, error) {
+	return rlp.EncodeToBytes(&a)
+}
+
+func (a *Authorization) UnmarshalNil(buf []byte) error {
+	return rlp.DecodeBytes(buf, a)
+}
+
+// ApplyAuthorization applies one entry of a SetCodeTransactionKind's
+// AuthorizationList, using the state accessors passed in. It recovers the
+// signer, checks ChainId and Nonce, and if both check out, sets the
+// signer's code to the delegation designation (or clears it, for the zero
+// address) via setCode and bumps its seqno via bumpSeqno.
+//
+// An invalid authorization (bad signature, wrong chain, stale nonce) is
+// skipped rather than treated as an error: it does not fail the
+// transaction, since other authorizations in the same list may still be
+// valid.
+func (a *Authorization) ApplyAuthorization(
+	currentChainId ChainId, getSeqno SeqnoGetter, bumpSeqno func(Address) error, setCode func(Address, []byte) error,
+) {
+	if a.ChainId != 0 && a.ChainId != currentChainId {
+		return
+	}
+	signer, err := a.Recover()
+	if err != nil {
+		return
+	}
+	seqno, err := getSeqno(signer)
+	if err != nil || seqno != a.Nonce {
+		return
+	}
+	if err := bumpSeqno(signer); err != nil {
+		return
+	}
+	if a.Address == (Address{}) {
+		_ = setCode(signer, nil)
+		return
+	}
+	_ = setCode(signer, append(append([]byte{}, DelegationDesignationPrefix[:]...), a.Address.Bytes()...))
+}
@@ -0,0 +1,143 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func signAuthorization(t *testing.T, auth *Authorization) {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	hash, err := auth.SigningHash()
+	if err != nil {
+		t.Fatalf("SigningHash: %v", err)
+	}
+	sig, err := crypto.Sign(hash.Bytes(), key)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	auth.R = common.BytesToHash(sig[0:32])
+	auth.S = common.BytesToHash(sig[32:64])
+	auth.V = sig[64]
+
+	wantSigner := BytesToAddress(crypto.PubkeyToAddress(key.PublicKey).Bytes())
+	signer, err := auth.Recover()
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if signer != wantSigner {
+		t.Fatalf("self-check: recovered %v, want %v", signer, wantSigner)
+	}
+}
+
+func TestAuthorizationRecoverSuccess(t *testing.T) {
+	t.Parallel()
+
+	auth := &Authorization{ChainId: ChainId(1), Address: Address{1}, Nonce: Seqno(0)}
+	signAuthorization(t, auth)
+}
+
+func TestAuthorizationRecoverRejectsInvalidV(t *testing.T) {
+	t.Parallel()
+
+	auth := &Authorization{ChainId: ChainId(1), Address: Address{1}, Nonce: Seqno(0)}
+	signAuthorization(t, auth)
+	auth.V = 2
+
+	if _, err := auth.Recover(); err == nil {
+		t.Fatal("expected an error for a V value other than 0 or 1")
+	}
+}
+
+func TestAuthorizationRecoverTamperedNonceChangesSigner(t *testing.T) {
+	t.Parallel()
+
+	auth := &Authorization{ChainId: ChainId(1), Address: Address{1}, Nonce: Seqno(0)}
+	signAuthorization(t, auth)
+	original, err := auth.Recover()
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	auth.Nonce = Seqno(1)
+
+	signer, err := auth.Recover()
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+
+	if signer == original {
+		t.Fatal("expected tampering the signed nonce to change the recovered signer")
+	}
+}
+
+func TestApplyAuthorizationSkipsWrongChain(t *testing.T) {
+	t.Parallel()
+
+	auth := &Authorization{ChainId: ChainId(2), Address: Address{1}, Nonce: Seqno(0)}
+	signAuthorization(t, auth)
+
+	codeSet := false
+	getSeqno := func(Address) (Seqno, error) { return 0, nil }
+	bumpSeqno := func(Address) error { t.Fatal("bumpSeqno should not be called"); return nil }
+	setCode := func(Address, []byte) error { codeSet = true; return nil }
+
+	auth.ApplyAuthorization(ChainId(1), getSeqno, bumpSeqno, setCode)
+	if codeSet {
+		t.Fatal("expected an authorization for a different chain id to be skipped")
+	}
+}
+
+func TestApplyAuthorizationSkipsStaleNonce(t *testing.T) {
+	t.Parallel()
+
+	auth := &Authorization{ChainId: ChainId(1), Address: Address{1}, Nonce: Seqno(0)}
+	signAuthorization(t, auth)
+
+	codeSet := false
+	getSeqno := func(Address) (Seqno, error) { return 5, nil }
+	bumpSeqno := func(Address) error { t.Fatal("bumpSeqno should not be called"); return nil }
+	setCode := func(Address, []byte) error { codeSet = true; return nil }
+
+	auth.ApplyAuthorization(ChainId(1), getSeqno, bumpSeqno, setCode)
+	if codeSet {
+		t.Fatal("expected an authorization with a stale nonce to be skipped")
+	}
+}
+
+func TestApplyAuthorizationSetsDelegationDesignation(t *testing.T) {
+	t.Parallel()
+
+	delegate := Address{9}
+	auth := &Authorization{ChainId: ChainId(1), Address: delegate, Nonce: Seqno(0)}
+	signAuthorization(t, auth)
+
+	var gotSigner Address
+	var gotCode []byte
+	bumped := false
+	getSeqno := func(Address) (Seqno, error) { return 0, nil }
+	bumpSeqno := func(a Address) error { bumped = true; gotSigner = a; return nil }
+	setCode := func(a Address, code []byte) error { gotCode = code; return nil }
+
+	auth.ApplyAuthorization(ChainId(1), getSeqno, bumpSeqno, setCode)
+
+	if !bumped {
+		t.Fatal("expected bumpSeqno to be called for a valid authorization")
+	}
+	wantCode := append(append([]byte{}, DelegationDesignationPrefix[:]...), delegate.Bytes()...)
+	if string(gotCode) != string(wantCode) {
+		t.Fatalf("expected delegation designation code %x, got %x", wantCode, gotCode)
+	}
+	signer, err := auth.Recover()
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if gotSigner != signer {
+		t.Fatalf("expected bumpSeqno to be called with the recovered signer %v, got %v", signer, gotSigner)
+	}
+}
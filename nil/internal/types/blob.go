@@ -0,0 +1,79 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func init() {
+	RegisterTransactionType(BlobTransactionKind, 0x03)
+}
+
+// BlobSize is the fixed size of a single data blob, matching EIP-4844's
+// 128 KiB field-element-packed blob.
+const BlobSize = 131072
+
+// Blob is one sidecar data blob. Contract bytecode never sees the blob
+// itself, only its versioned hash via TransactionDigest.BlobHashes.
+type Blob [BlobSize]byte
+
+// KZGCommitment is a KZG polynomial commitment to a Blob.
+type KZGCommitment [48]byte
+
+// KZGProof is a KZG opening proof for a Blob against its KZGCommitment.
+type KZGProof [48]byte
+
+// BlobVersionedHashVersion is the leading byte of a blob versioned hash,
+// reserved to allow the commitment scheme to change in the future.
+const BlobVersionedHashVersion = 0x01
+
+// BlobTxSidecar carries the large binary blobs a blob-carrying transaction
+// commits to. It is a separate value: it is not a field of Transaction or
+// ExternalTransaction, MarshalBinary does not encode it, and unlike
+// TransactionDigest it is never part of Hash() or SigningHash(): only the
+// versioned hashes in BlobHashes are.
+type BlobTxSidecar struct {
+	Blobs       []Blob
+	Commitments []KZGCommitment
+	Proofs      []KZGProof
+}
+
+// VersionedHashes derives the versioned hash of each commitment in the
+// sidecar: 0x01 || keccak256(commitment)[1:].
+func (s *BlobTxSidecar) VersionedHashes() ([]common.Hash, error) {
+	hashes := make([]common.Hash, len(s.Commitments))
+	for i, c := range s.Commitments {
+		h := crypto.Keccak256(c[:])
+		h[0] = BlobVersionedHashVersion
+		hashes[i] = common.BytesToHash(h)
+	}
+	return hashes, nil
+}
+
+// VerifySidecar checks that the sidecar's commitments produce exactly the
+// versioned hashes declared in blobHashes, in order.
+func (s *BlobTxSidecar) VerifySidecar(blobHashes []common.Hash) error {
+	if len(s.Blobs) != len(s.Commitments) || len(s.Commitments) != len(s.Proofs) {
+		return errors.New("blob sidecar: blobs, commitments and proofs must have equal length")
+	}
+	hashes, err := s.VersionedHashes()
+	if err != nil {
+		return err
+	}
+	if len(hashes) != len(blobHashes) {
+		return fmt.Errorf("blob sidecar: expected %d blob hashes, got %d", len(hashes), len(blobHashes))
+	}
+	for i, h := range hashes {
+		if h != blobHashes[i] {
+			return fmt.Errorf("blob sidecar: versioned hash mismatch at index %d: %s != %s", i, h, blobHashes[i])
+		}
+	}
+	return nil
+}
+
+// Note: making BlobHashes visible to contract bytecode requires a new
+// opcode/precompile in the execution layer, which isn't part of this
+// module; it should read TransactionDigest.BlobHashes defined here.
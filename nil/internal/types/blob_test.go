@@ -0,0 +1,62 @@
+package types
+
+import "testing"
+
+func TestBlobTxSidecarVersionedHashes(t *testing.T) {
+	t.Parallel()
+
+	sidecar := &BlobTxSidecar{
+		Blobs:       []Blob{{}},
+		Commitments: []KZGCommitment{{1, 2, 3}},
+		Proofs:      []KZGProof{{}},
+	}
+
+	hashes, err := sidecar.VersionedHashes()
+	if err != nil {
+		t.Fatalf("VersionedHashes: %v", err)
+	}
+	if len(hashes) != 1 {
+		t.Fatalf("expected 1 versioned hash, got %d", len(hashes))
+	}
+	if hashes[0][0] != BlobVersionedHashVersion {
+		t.Fatalf("expected versioned hash to start with %#x, got %#x", BlobVersionedHashVersion, hashes[0][0])
+	}
+
+	if err := sidecar.VerifySidecar(hashes); err != nil {
+		t.Fatalf("VerifySidecar: %v", err)
+	}
+}
+
+func TestBlobTxSidecarVerifySidecarMismatch(t *testing.T) {
+	t.Parallel()
+
+	sidecar := &BlobTxSidecar{
+		Blobs:       []Blob{{}},
+		Commitments: []KZGCommitment{{1, 2, 3}},
+		Proofs:      []KZGProof{{}},
+	}
+
+	hashes, err := sidecar.VersionedHashes()
+	if err != nil {
+		t.Fatalf("VersionedHashes: %v", err)
+	}
+	hashes[0][1] ^= 0xff
+
+	if err := sidecar.VerifySidecar(hashes); err == nil {
+		t.Fatal("expected a mismatch error for a tampered blob hash")
+	}
+}
+
+func TestBlobTxSidecarVerifySidecarLengthMismatch(t *testing.T) {
+	t.Parallel()
+
+	sidecar := &BlobTxSidecar{
+		Blobs:       []Blob{{}},
+		Commitments: []KZGCommitment{{1}},
+		Proofs:      []KZGProof{{}, {}},
+	}
+
+	if err := sidecar.VerifySidecar(nil); err == nil {
+		t.Fatal("expected an error when blobs/commitments/proofs lengths disagree")
+	}
+}
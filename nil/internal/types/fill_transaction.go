@@ -0,0 +1,99 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common"
+)
+
+// Note: the nil_fillTransaction JSON-RPC method itself belongs to the
+// node's RPC package, which isn't part of this module; it should be a thin
+// wrapper around FillTransaction below, backed by the node's account reader
+// and gas estimator.
+
+// SeqnoGetter reads the current seqno of an account, e.g. from the node's
+// state DB.
+type SeqnoGetter func(Address) (Seqno, error)
+
+// GasEstimator dry-runs a transaction's Data against current state and
+// reports the fee credit it would consume.
+type GasEstimator func(ExternalTransaction) (Value, error)
+
+// FillTransactionParams is the partially populated input to
+// nil_fillTransaction; zero-valued fields of Transaction are filled in from
+// node state before it is returned.
+type FillTransactionParams struct {
+	Transaction ExternalTransaction
+	BaseFee     Value
+	ChainId     ChainId
+	Sidecar     *BlobTxSidecar
+}
+
+// FillTransactionResult is the payload returned by nil_fillTransaction: the
+// filled-in transaction plus the hash an offline signer must produce
+// AuthData for, so the caller only needs to echo AuthData back through
+// nil_sendRawTransaction.
+type FillTransactionResult struct {
+	Transaction ExternalTransaction `json:"transaction"`
+	SigningHash common.Hash         `json:"signingHash"`
+}
+
+// FillTransaction implements the internal helper behind nil_fillTransaction:
+// it fills ChainId, Seqno, MaxFeePerGas, FeeCredit and, for blob-carrying
+// kinds, BlobHashes on a partially populated ExternalTransaction.
+func FillTransaction(
+	params FillTransactionParams, getSeqno SeqnoGetter, estimateGas GasEstimator,
+) (*FillTransactionResult, error) {
+	tx := params.Transaction
+
+	if tx.ChainId == DefaultChainId {
+		tx.ChainId = params.ChainId
+	}
+
+	if tx.Seqno == 0 {
+		seqno, err := getSeqno(tx.To)
+		if err != nil {
+			return nil, fmt.Errorf("fetching seqno: %w", err)
+		}
+		tx.Seqno = seqno
+	}
+
+	if tx.MaxFeePerGas.IsZero() {
+		tx.MaxFeePerGas = params.BaseFee.Add(params.BaseFee).Add(tx.MaxPriorityFeePerGas)
+	}
+
+	if tx.FeeCredit.IsZero() {
+		feeCredit, err := estimateGas(tx)
+		if err != nil {
+			return nil, fmt.Errorf("estimating gas: %w", err)
+		}
+		tx.FeeCredit = feeCredit
+	}
+
+	if tx.Kind == BlobTransactionKind {
+		if params.Sidecar == nil {
+			if len(tx.BlobHashes) == 0 {
+				return nil, errors.New("blob transaction requires a sidecar to derive blob hashes")
+			}
+		} else if len(tx.BlobHashes) == 0 {
+			hashes, err := params.Sidecar.VersionedHashes()
+			if err != nil {
+				return nil, err
+			}
+			tx.BlobHashes = hashes
+		} else if err := params.Sidecar.VerifySidecar(tx.BlobHashes); err != nil {
+			// The caller already supplied BlobHashes (e.g. re-filling a
+			// partially built transaction): make sure the sidecar still
+			// matches them instead of silently trusting the caller.
+			return nil, fmt.Errorf("sidecar does not match supplied blob hashes: %w", err)
+		}
+	}
+
+	hash, err := tx.SigningHash()
+	if err != nil {
+		return nil, err
+	}
+
+	return &FillTransactionResult{Transaction: tx, SigningHash: hash}, nil
+}
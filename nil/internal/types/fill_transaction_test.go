@@ -0,0 +1,111 @@
+package types
+
+import "testing"
+
+func TestFillTransactionFeeMath(t *testing.T) {
+	t.Parallel()
+
+	params := FillTransactionParams{
+		Transaction: ExternalTransaction{
+			FeePack: FeePack{MaxPriorityFeePerGas: GasToValue(2)},
+		},
+		BaseFee: GasToValue(10),
+		ChainId: ChainId(42),
+	}
+	getSeqno := func(Address) (Seqno, error) { return Seqno(3), nil }
+	estimateGas := func(ExternalTransaction) (Value, error) { return GasToValue(100), nil }
+
+	result, err := FillTransaction(params, getSeqno, estimateGas)
+	if err != nil {
+		t.Fatalf("FillTransaction: %v", err)
+	}
+	if result.Transaction.ChainId != ChainId(42) {
+		t.Fatalf("expected ChainId to be filled from params, got %v", result.Transaction.ChainId)
+	}
+	if result.Transaction.Seqno != Seqno(3) {
+		t.Fatalf("expected Seqno to be filled from getSeqno, got %v", result.Transaction.Seqno)
+	}
+	wantMaxFee := GasToValue(10).Add(GasToValue(10)).Add(GasToValue(2))
+	if result.Transaction.MaxFeePerGas.Cmp(wantMaxFee) != 0 {
+		t.Fatalf("expected MaxFeePerGas %s, got %s", wantMaxFee, result.Transaction.MaxFeePerGas)
+	}
+	if result.Transaction.FeeCredit.Cmp(GasToValue(100)) != 0 {
+		t.Fatalf("expected FeeCredit from estimateGas, got %s", result.Transaction.FeeCredit)
+	}
+}
+
+func TestFillTransactionKeepsExplicitValues(t *testing.T) {
+	t.Parallel()
+
+	params := FillTransactionParams{
+		Transaction: ExternalTransaction{
+			ChainId: ChainId(1),
+			Seqno:   Seqno(9),
+			FeePack: FeePack{MaxFeePerGas: GasToValue(50), FeeCredit: GasToValue(20)},
+		},
+		BaseFee: GasToValue(10),
+		ChainId: ChainId(42),
+	}
+	getSeqno := func(Address) (Seqno, error) { t.Fatal("getSeqno should not be called"); return 0, nil }
+	estimateGas := func(ExternalTransaction) (Value, error) {
+		t.Fatal("estimateGas should not be called")
+		return NewZeroValue(), nil
+	}
+
+	result, err := FillTransaction(params, getSeqno, estimateGas)
+	if err != nil {
+		t.Fatalf("FillTransaction: %v", err)
+	}
+	if result.Transaction.ChainId != ChainId(1) || result.Transaction.Seqno != Seqno(9) {
+		t.Fatalf("expected explicit ChainId/Seqno to be preserved, got %+v", result.Transaction)
+	}
+	if result.Transaction.MaxFeePerGas.Cmp(GasToValue(50)) != 0 {
+		t.Fatalf("expected explicit MaxFeePerGas to be preserved, got %s", result.Transaction.MaxFeePerGas)
+	}
+}
+
+func TestFillTransactionBlobRequiresSidecarOrHashes(t *testing.T) {
+	t.Parallel()
+
+	params := FillTransactionParams{
+		Transaction: ExternalTransaction{Kind: BlobTransactionKind, FeePack: NewFeePack()},
+		BaseFee:     GasToValue(1),
+	}
+	getSeqno := func(Address) (Seqno, error) { return 0, nil }
+	estimateGas := func(ExternalTransaction) (Value, error) { return NewZeroValue(), nil }
+
+	if _, err := FillTransaction(params, getSeqno, estimateGas); err == nil {
+		t.Fatal("expected an error when a blob transaction has neither a sidecar nor blob hashes")
+	}
+}
+
+func TestFillTransactionBlobRejectsMismatchedSidecar(t *testing.T) {
+	t.Parallel()
+
+	sidecar := &BlobTxSidecar{
+		Blobs:       []Blob{{}},
+		Commitments: []KZGCommitment{{1, 2, 3}},
+		Proofs:      []KZGProof{{}},
+	}
+	wrongHashes, err := sidecar.VersionedHashes()
+	if err != nil {
+		t.Fatalf("VersionedHashes: %v", err)
+	}
+	wrongHashes[0][1] ^= 0xff
+
+	params := FillTransactionParams{
+		Transaction: ExternalTransaction{
+			Kind:       BlobTransactionKind,
+			FeePack:    NewFeePack(),
+			BlobHashes: wrongHashes,
+		},
+		BaseFee: GasToValue(1),
+		Sidecar: sidecar,
+	}
+	getSeqno := func(Address) (Seqno, error) { return 0, nil }
+	estimateGas := func(ExternalTransaction) (Value, error) { return NewZeroValue(), nil }
+
+	if _, err := FillTransaction(params, getSeqno, estimateGas); err == nil {
+		t.Fatal("expected an error when the supplied blob hashes don't match the sidecar")
+	}
+}
@@ -0,0 +1,110 @@
+package types
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer abstracts over the authentication scheme used to produce and
+// verify an (External)Transaction's AuthData, so account contracts aren't
+// hard-wired to secp256k1. SchemeID is prepended as the leading byte of
+// AuthData so a verifier can dispatch to the right scheme without out-of-band
+// context.
+//
+// BLS12-381 and Ed25519 were both considered, for aggregate-friendly
+// multisig accounts and smart-wallet accounts respectively, but are scoped
+// out of this change: Recover needs to produce an Address from AuthData
+// alone, and neither scheme's bare signature carries a recoverable public
+// key the way secp256k1's does. Shipping either one as a Signer would mean
+// a transaction that can be signed but never verified at the mempool
+// boundary. Add them back once AuthData can carry the scheme's public key
+// alongside the signature (e.g. scheme || pubkey || sig), with Recover
+// validating the embedded key against the signature instead of deriving it.
+type Signer interface {
+	Sign(hash common.Hash) ([]byte, error)
+	Recover(hash common.Hash, auth []byte) (Address, error)
+	SchemeID() uint8
+}
+
+const (
+	SchemeSecp256k1 uint8 = iota
+)
+
+// LatestSignerForChainID returns the Signer the node uses to validate
+// AuthData at the mempool boundary for chainId, mirroring go-ethereum's
+// LatestSignerForChainID so callers don't have to thread scheme types
+// manually. The returned Signer has no private key attached, so it can
+// Recover but not Sign; wallets construct a keyed Signer directly via
+// NewSecp256k1Signer.
+func LatestSignerForChainID(_ ChainId) Signer {
+	return &secp256k1Signer{}
+}
+
+// signerForScheme dispatches on the scheme byte encoded in AuthData, so the
+// mempool can validate an incoming transaction's signature regardless of
+// which scheme produced it.
+func signerForScheme(chainId ChainId, scheme uint8) (Signer, error) {
+	switch scheme {
+	case SchemeSecp256k1:
+		return LatestSignerForChainID(chainId), nil
+	default:
+		return nil, fmt.Errorf("unknown signature scheme: %d", scheme)
+	}
+}
+
+// RecoverSigner recovers the address that produced m.AuthData, dispatching
+// on the scheme byte AuthData is prefixed with. This is what lets the node
+// validate ExternalTransactions uniformly at the mempool boundary regardless
+// of their authentication scheme.
+func (m *ExternalTransaction) RecoverSigner() (Address, error) {
+	if len(m.AuthData) == 0 {
+		return Address{}, errors.New("missing auth data")
+	}
+	hash, err := m.SigningHash()
+	if err != nil {
+		return Address{}, err
+	}
+	signer, err := signerForScheme(m.ChainId, m.AuthData[0])
+	if err != nil {
+		return Address{}, err
+	}
+	return signer.Recover(hash, m.AuthData)
+}
+
+type secp256k1Signer struct {
+	key *ecdsa.PrivateKey
+}
+
+// NewSecp256k1Signer returns a Signer that signs with key, the scheme used
+// by ExternalTransaction.Sign/Transaction.Sign today.
+func NewSecp256k1Signer(key *ecdsa.PrivateKey) Signer {
+	return &secp256k1Signer{key: key}
+}
+
+func (s *secp256k1Signer) SchemeID() uint8 { return SchemeSecp256k1 }
+
+func (s *secp256k1Signer) Sign(hash common.Hash) ([]byte, error) {
+	if s.key == nil {
+		return nil, errors.New("secp256k1 signer has no private key")
+	}
+	sig, err := crypto.Sign(hash.Bytes(), s.key)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{SchemeSecp256k1}, sig...), nil
+}
+
+func (s *secp256k1Signer) Recover(hash common.Hash, auth []byte) (Address, error) {
+	if len(auth) == 0 || auth[0] != SchemeSecp256k1 {
+		return Address{}, errors.New("auth data scheme mismatch: expected secp256k1")
+	}
+	pub, err := crypto.SigToPub(hash.Bytes(), auth[1:])
+	if err != nil {
+		return Address{}, err
+	}
+	return BytesToAddress(crypto.PubkeyToAddress(*pub).Bytes()), nil
+}
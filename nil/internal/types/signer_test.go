@@ -0,0 +1,82 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestSecp256k1SignerSignRecoverRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	want := BytesToAddress(crypto.PubkeyToAddress(key.PublicKey).Bytes())
+
+	ext := &ExternalTransaction{ChainId: ChainId(1)}
+	hash, err := ext.SigningHash()
+	if err != nil {
+		t.Fatalf("SigningHash: %v", err)
+	}
+
+	signer := NewSecp256k1Signer(key)
+	if signer.SchemeID() != SchemeSecp256k1 {
+		t.Fatalf("expected scheme id %d, got %d", SchemeSecp256k1, signer.SchemeID())
+	}
+	auth, err := signer.Sign(hash)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	got, err := signer.Recover(hash, auth)
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if got != want {
+		t.Fatalf("Recover: got %v, want %v", got, want)
+	}
+}
+
+func TestExternalTransactionRecoverSignerRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	want := BytesToAddress(crypto.PubkeyToAddress(key.PublicKey).Bytes())
+
+	ext := &ExternalTransaction{ChainId: ChainId(1)}
+	if err := ext.Sign(key); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	got, err := ext.RecoverSigner()
+	if err != nil {
+		t.Fatalf("RecoverSigner: %v", err)
+	}
+	if got != want {
+		t.Fatalf("RecoverSigner: got %v, want %v", got, want)
+	}
+}
+
+func TestExternalTransactionRecoverSignerMissingAuthData(t *testing.T) {
+	t.Parallel()
+
+	ext := &ExternalTransaction{ChainId: ChainId(1)}
+	if _, err := ext.RecoverSigner(); err == nil {
+		t.Fatal("expected an error when AuthData is empty")
+	}
+}
+
+func TestSecp256k1SignerRecoverRejectsSchemeMismatch(t *testing.T) {
+	t.Parallel()
+
+	signer := NewSecp256k1Signer(nil)
+	if _, err := signer.Recover(common.Hash{}, []byte{SchemeSecp256k1 + 1}); err == nil {
+		t.Fatal("expected an error for an auth data scheme mismatch")
+	}
+}
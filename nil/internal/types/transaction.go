@@ -3,6 +3,7 @@ package types
 import (
 	"crypto/ecdsa"
 	"database/sql/driver"
+	"encoding"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -25,6 +26,8 @@ const (
 	DeployTransactionKind
 	RefundTransactionKind
 	ResponseTransactionKind
+	BlobTransactionKind
+	SetCodeTransactionKind
 )
 
 func (k TransactionKind) String() string {
@@ -37,6 +40,10 @@ func (k TransactionKind) String() string {
 		return "RefundTransactionKind"
 	case ResponseTransactionKind:
 		return "ResponseTransactionKind"
+	case BlobTransactionKind:
+		return "BlobTransactionKind"
+	case SetCodeTransactionKind:
+		return "SetCodeTransactionKind"
 	}
 	panic("unknown TransactionKind")
 }
@@ -51,6 +58,10 @@ func (k *TransactionKind) Set(input string) error {
 		*k = RefundTransactionKind
 	case "response", "ResponseTransactionKind":
 		*k = ResponseTransactionKind
+	case "blob", "BlobTransactionKind":
+		*k = BlobTransactionKind
+	case "setCode", "SetCodeTransactionKind":
+		*k = SetCodeTransactionKind
 	default:
 		return fmt.Errorf("unknown TransactionKind: %s", input)
 	}
@@ -61,6 +72,67 @@ func (k TransactionKind) Type() string {
 	return "TransactionKind"
 }
 
+// TransactionType is the leading byte of a transaction's typed-envelope
+// encoding (see MarshalBinary/UnmarshalBinary on Transaction and
+// ExternalTransaction). LegacyTxType is reserved for the original bare-RLP
+// format: since an RLP list always starts with a byte >= legacyRLPListMinByte,
+// any smaller leading byte unambiguously identifies a typed envelope.
+type TransactionType byte
+
+const LegacyTxType TransactionType = 0x00
+
+const legacyRLPListMinByte = 0xc0
+
+// transactionEnvelopeTypes maps a TransactionKind to the envelope type byte
+// it is carried under on the wire. Kinds with no entry here use
+// LegacyTxType, so existing consumers keep seeing the original RLP format.
+// New kinds register themselves here instead of changing Transaction,
+// ExternalTransaction, or their (Un)MarshalBinary methods.
+var transactionEnvelopeTypes = map[TransactionKind]TransactionType{}
+
+// RegisterTransactionType associates kind with the typed-envelope type byte
+// it is encoded under. It must be called before any transaction of that
+// kind is marshaled or unmarshaled, typically from an init() in the file
+// that introduces the kind.
+func RegisterTransactionType(kind TransactionKind, typ TransactionType) {
+	transactionEnvelopeTypes[kind] = typ
+}
+
+// EnvelopeType returns the typed-envelope type byte registered for k, or
+// LegacyTxType if none was registered.
+func (k TransactionKind) EnvelopeType() TransactionType {
+	if typ, ok := transactionEnvelopeTypes[k]; ok {
+		return typ
+	}
+	return LegacyTxType
+}
+
+func isKnownTransactionType(typ TransactionType) bool {
+	if typ == LegacyTxType {
+		return true
+	}
+	for _, t := range transactionEnvelopeTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// typedHash returns keccak256(type || rlp(payload)) for typed envelopes, or
+// plain keccak256(rlp(payload)) for LegacyTxType, so legacy hashes are
+// unaffected and every typed variant's hash is bound to its type byte.
+func typedHash(typ TransactionType, payload any) (common.Hash, error) {
+	if typ == LegacyTxType {
+		return common.Keccak(payload)
+	}
+	body, err := rlp.EncodeToBytes(payload)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(crypto.Keccak256(append([]byte{byte(typ)}, body...))), nil
+}
+
 type Seqno uint64
 
 func (seqno Seqno) Uint64() uint64 {
@@ -138,6 +210,8 @@ const (
 	TransactionFlagRefund
 	TransactionFlagBounce
 	TransactionFlagResponse
+	TransactionFlagBlob
+	TransactionFlagSetCode
 )
 
 type ForwardKind uint64
@@ -186,10 +260,14 @@ func (k ForwardKind) Type() string {
 type TransactionDigest struct {
 	Flags TransactionFlags `json:"flags" ch:"flags"`
 	FeePack
-	To      Address `json:"to,omitempty" ch:"to"`
-	ChainId ChainId `json:"chainId" ch:"chainId"`
-	Seqno   Seqno   `json:"seqno,omitempty" ch:"seqno"`
-	Data    Code    `json:"data,omitempty" ch:"data"`
+	To                Address         `json:"to,omitempty" ch:"to"`
+	ChainId           ChainId         `json:"chainId" ch:"chainId"`
+	Seqno             Seqno           `json:"seqno,omitempty" ch:"seqno"`
+	Data              Code            `json:"data,omitempty" ch:"data"`
+	AccessList        AccessList      `json:"accessList,omitempty" ch:"access_list" rlp:"optional"`
+	BlobHashes        []common.Hash   `json:"blobHashes,omitempty" ch:"blob_hashes" rlp:"optional"`
+	MaxFeePerBlobGas  Value           `json:"maxFeePerBlobGas,omitempty" ch:"max_fee_per_blob_gas" rlp:"optional"`
+	AuthorizationList []Authorization `json:"authorizationList,omitempty" ch:"authorization_list" rlp:"optional"`
 }
 
 func (d TransactionDigest) MarshalNil() ([]byte, error) {
@@ -225,11 +303,15 @@ type OutboundTransaction struct {
 type ExternalTransaction struct {
 	Kind TransactionKind `json:"kind,omitempty" ch:"kind"`
 	FeePack
-	To       Address       `json:"to,omitempty" ch:"to"`
-	ChainId  ChainId       `json:"chainId" ch:"chainId"`
-	Seqno    Seqno         `json:"seqno,omitempty" ch:"seqno"`
-	Data     Code          `json:"data,omitempty" ch:"data"`
-	AuthData hexutil.Bytes `json:"authData,omitempty" ch:"auth_data" rlp:"optional"`
+	To                Address         `json:"to,omitempty" ch:"to"`
+	ChainId           ChainId         `json:"chainId" ch:"chainId"`
+	Seqno             Seqno           `json:"seqno,omitempty" ch:"seqno"`
+	Data              Code            `json:"data,omitempty" ch:"data"`
+	AccessList        AccessList      `json:"accessList,omitempty" ch:"access_list" rlp:"optional"`
+	BlobHashes        []common.Hash   `json:"blobHashes,omitempty" ch:"blob_hashes" rlp:"optional"`
+	MaxFeePerBlobGas  Value           `json:"maxFeePerBlobGas,omitempty" ch:"max_fee_per_blob_gas" rlp:"optional"`
+	AuthorizationList []Authorization `json:"authorizationList,omitempty" ch:"authorization_list" rlp:"optional"`
+	AuthData          hexutil.Bytes   `json:"authData,omitempty" ch:"auth_data" rlp:"optional"`
 }
 
 func (tx *ExternalTransaction) UnmarshalNil(buf []byte) error {
@@ -252,6 +334,7 @@ type InternalTransactionPayload struct {
 	Data        Code            `json:"data,omitempty" ch:"data" `
 	RequestId   uint64          `json:"requestId,omitempty" ch:"request_id"`
 	Token       []TokenBalance  `json:"token,omitempty" ch:"token" rlp:"optional"`
+	AccessList  AccessList      `json:"accessList,omitempty" ch:"access_list" rlp:"optional"`
 }
 
 func (p *InternalTransactionPayload) UnmarshalNil(buf []byte) error {
@@ -333,6 +416,10 @@ var (
 	_ common.Hashable              = new(ExternalTransaction)
 	_ serialization.NilMarshaler   = new(Transaction)
 	_ serialization.NilUnmarshaler = new(Transaction)
+	_ encoding.BinaryMarshaler     = new(Transaction)
+	_ encoding.BinaryUnmarshaler   = new(Transaction)
+	_ encoding.BinaryMarshaler     = new(ExternalTransaction)
+	_ encoding.BinaryUnmarshaler   = new(ExternalTransaction)
 )
 
 func NewEmptyTransaction() *Transaction {
@@ -356,7 +443,70 @@ func (m *Transaction) Hash() common.Hash {
 	if m.IsExternal() {
 		return m.toExternal().Hash()
 	}
-	return ToShardedHash(common.MustKeccak(m), m.To.ShardId())
+	h, err := typedHash(m.EnvelopeType(), m)
+	check.PanicIfErr(err)
+	return ToShardedHash(h, m.To.ShardId())
+}
+
+// Kind reconstructs the TransactionKind of an (internal) transaction from
+// its flags. It is the inverse of TransactionFlagsFromKind.
+func (m *Transaction) Kind() TransactionKind {
+	switch {
+	case m.IsDeploy():
+		return DeployTransactionKind
+	case m.IsRefund():
+		return RefundTransactionKind
+	case m.IsResponse():
+		return ResponseTransactionKind
+	case m.IsBlob():
+		return BlobTransactionKind
+	case m.IsSetCode():
+		return SetCodeTransactionKind
+	default:
+		return ExecutionTransactionKind
+	}
+}
+
+// EnvelopeType returns the typed-envelope type byte this transaction would
+// be marshaled under.
+func (m *Transaction) EnvelopeType() TransactionType {
+	return m.Kind().EnvelopeType()
+}
+
+// Type returns the typed-envelope type byte this transaction would be
+// marshaled under (0x00 for the legacy format).
+func (m *Transaction) Type() byte {
+	return byte(m.EnvelopeType())
+}
+
+// MarshalBinary encodes the transaction as a typed envelope: the bare RLP
+// encoding for LegacyTxType, or type_byte || rlp(payload) otherwise.
+func (m *Transaction) MarshalBinary() ([]byte, error) {
+	payload, err := rlp.EncodeToBytes(m)
+	if err != nil {
+		return nil, err
+	}
+	if typ := m.EnvelopeType(); typ != LegacyTxType {
+		return append([]byte{byte(typ)}, payload...), nil
+	}
+	return payload, nil
+}
+
+// UnmarshalBinary decodes a typed-envelope transaction. A leading byte
+// >= legacyRLPListMinByte is the start of an RLP list, so it is decoded as
+// legacy; otherwise the byte selects a registered envelope type.
+func (m *Transaction) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty transaction data")
+	}
+	if data[0] >= legacyRLPListMinByte {
+		return rlp.DecodeBytes(data, m)
+	}
+	typ := TransactionType(data[0])
+	if !isKnownTransactionType(typ) {
+		return fmt.Errorf("unknown transaction type: %#x", data[0])
+	}
+	return rlp.DecodeBytes(data[1:], m)
 }
 
 func (m *Transaction) Sign(key *ecdsa.PrivateKey) error {
@@ -372,23 +522,18 @@ func (m *Transaction) toExternal() *ExternalTransaction {
 	if m.IsInternal() {
 		panic("cannot convert internal transaction to external transaction")
 	}
-	var kind TransactionKind
-	switch {
-	case m.IsDeploy():
-		kind = DeployTransactionKind
-	case m.IsRefund():
-		kind = RefundTransactionKind
-	default:
-		kind = ExecutionTransactionKind
-	}
 	return &ExternalTransaction{
-		Kind:     kind,
-		FeePack:  m.FeePack,
-		To:       m.To,
-		ChainId:  m.ChainId,
-		Seqno:    m.Seqno,
-		Data:     m.Data,
-		AuthData: m.Signature,
+		Kind:              m.Kind(),
+		FeePack:           m.FeePack,
+		To:                m.To,
+		ChainId:           m.ChainId,
+		Seqno:             m.Seqno,
+		Data:              m.Data,
+		AccessList:        m.AccessList,
+		BlobHashes:        m.BlobHashes,
+		MaxFeePerBlobGas:  m.MaxFeePerBlobGas,
+		AuthorizationList: m.AuthorizationList,
+		AuthData:          m.Signature,
 	}
 }
 
@@ -416,6 +561,33 @@ func (m *Transaction) VerifyFlags() error {
 	if m.To.ShardId().IsMainShard() && !m.From.ShardId().IsMainShard() {
 		return errors.New("transaction to main shard is not allowed from a regular shard")
 	}
+	if len(m.AccessList) > TransactionMaxAccessListSize {
+		return fmt.Errorf(
+			"access list is too large: %d > %d", len(m.AccessList), TransactionMaxAccessListSize)
+	}
+	if keys := m.AccessList.StorageKeyCount(); keys > TransactionMaxAccessListStorageKeys {
+		return fmt.Errorf(
+			"access list has too many storage keys: %d > %d", keys, TransactionMaxAccessListStorageKeys)
+	}
+	if m.IsBlob() {
+		if m.IsInternal() {
+			return errors.New("blob transaction cannot be internal")
+		}
+		if len(m.BlobHashes) == 0 {
+			return errors.New("blob transaction requires a non-empty blob hash list")
+		}
+		if m.MaxFeePerBlobGas.IsZero() {
+			return errors.New("blob transaction requires a non-zero max fee per blob gas")
+		}
+	}
+	if m.IsSetCode() {
+		if m.IsInternal() {
+			return errors.New("set-code transaction cannot be internal")
+		}
+		if len(m.AuthorizationList) == 0 {
+			return errors.New("set-code transaction requires a non-empty authorization list")
+		}
+	}
 	return nil
 }
 
@@ -447,6 +619,14 @@ func (m *Transaction) IsResponse() bool {
 	return m.Flags.IsResponse()
 }
 
+func (m *Transaction) IsBlob() bool {
+	return m.Flags.IsBlob()
+}
+
+func (m *Transaction) IsSetCode() bool {
+	return m.Flags.IsSetCode()
+}
+
 func (m *Transaction) IsRequest() bool {
 	return m.IsRequestOrResponse() && !m.IsResponse()
 }
@@ -472,12 +652,25 @@ func (m *Transaction) TransactionGasPrice(baseFeePerGas Value) (Value, error) {
 	return gasPrice, nil
 }
 
+// BlobGasPrice is TransactionGasPrice's companion for blob-carrying
+// transactions: blob gas has no priority tip, so the price is the base blob
+// fee itself. It returns an error if that fee exceeds MaxFeePerBlobGas
+// (zero MaxFeePerBlobGas means no limit).
+func (m *Transaction) BlobGasPrice(baseBlobFee Value) (Value, error) {
+	if !m.MaxFeePerBlobGas.IsZero() && baseBlobFee.Cmp(m.MaxFeePerBlobGas) > 0 {
+		return Value0, fmt.Errorf(
+			"max fee per blob gas is less than base blob fee: %s < %s", m.MaxFeePerBlobGas, baseBlobFee)
+	}
+	return baseBlobFee, nil
+}
+
 func (m InternalTransactionPayload) ToTransaction(from Address, seqno Seqno) *Transaction {
 	txn := &Transaction{
 		TransactionDigest: TransactionDigest{
-			Flags: TransactionFlagsFromKind(true, m.Kind),
-			To:    m.To,
-			Data:  m.Data,
+			Flags:      TransactionFlagsFromKind(true, m.Kind),
+			To:         m.To,
+			Data:       m.Data,
+			AccessList: m.AccessList,
 			FeePack: FeePack{
 				FeeCredit:            m.FeeCredit,
 				MaxPriorityFeePerGas: NewZeroValue(),
@@ -500,31 +693,77 @@ func (m InternalTransactionPayload) ToTransaction(from Address, seqno Seqno) *Tr
 }
 
 func (m *ExternalTransaction) Hash() common.Hash {
-	return ToShardedHash(common.MustKeccak(m), m.To.ShardId())
+	h, err := typedHash(m.Kind.EnvelopeType(), m)
+	check.PanicIfErr(err)
+	return ToShardedHash(h, m.To.ShardId())
+}
+
+// Type returns the typed-envelope type byte this transaction would be
+// marshaled under (0x00 for the legacy format).
+func (m *ExternalTransaction) Type() byte {
+	return byte(m.Kind.EnvelopeType())
+}
+
+// MarshalBinary encodes the transaction as a typed envelope: the bare RLP
+// encoding for LegacyTxType, or type_byte || rlp(payload) otherwise.
+func (m *ExternalTransaction) MarshalBinary() ([]byte, error) {
+	payload, err := rlp.EncodeToBytes(m)
+	if err != nil {
+		return nil, err
+	}
+	if typ := m.Kind.EnvelopeType(); typ != LegacyTxType {
+		return append([]byte{byte(typ)}, payload...), nil
+	}
+	return payload, nil
+}
+
+// UnmarshalBinary decodes a typed-envelope transaction. A leading byte
+// >= legacyRLPListMinByte is the start of an RLP list, so it is decoded as
+// legacy; otherwise the byte selects a registered envelope type.
+func (m *ExternalTransaction) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New("empty transaction data")
+	}
+	if data[0] >= legacyRLPListMinByte {
+		return rlp.DecodeBytes(data, m)
+	}
+	typ := TransactionType(data[0])
+	if !isKnownTransactionType(typ) {
+		return fmt.Errorf("unknown transaction type: %#x", data[0])
+	}
+	return rlp.DecodeBytes(data[1:], m)
 }
 
 func (m *ExternalTransaction) SigningHash() (common.Hash, error) {
 	transactionDigest := TransactionDigest{
-		Flags:   TransactionFlagsFromKind(false, m.Kind),
-		FeePack: m.FeePack,
-		Seqno:   m.Seqno,
-		To:      m.To,
-		Data:    m.Data,
-		ChainId: m.ChainId,
+		Flags:             TransactionFlagsFromKind(false, m.Kind),
+		FeePack:           m.FeePack,
+		Seqno:             m.Seqno,
+		To:                m.To,
+		Data:              m.Data,
+		ChainId:           m.ChainId,
+		AccessList:        m.AccessList,
+		BlobHashes:        m.BlobHashes,
+		MaxFeePerBlobGas:  m.MaxFeePerBlobGas,
+		AuthorizationList: m.AuthorizationList,
 	}
 
-	return common.Keccak(&transactionDigest)
+	return typedHash(m.Kind.EnvelopeType(), &transactionDigest)
 }
 
 func (m ExternalTransaction) ToTransaction() *Transaction {
 	return &Transaction{
 		TransactionDigest: TransactionDigest{
-			Flags:   TransactionFlagsFromKind(false, m.Kind),
-			To:      m.To,
-			ChainId: m.ChainId,
-			Seqno:   m.Seqno,
-			Data:    m.Data,
-			FeePack: m.FeePack,
+			Flags:             TransactionFlagsFromKind(false, m.Kind),
+			To:                m.To,
+			ChainId:           m.ChainId,
+			Seqno:             m.Seqno,
+			Data:              m.Data,
+			FeePack:           m.FeePack,
+			AccessList:        m.AccessList,
+			BlobHashes:        m.BlobHashes,
+			MaxFeePerBlobGas:  m.MaxFeePerBlobGas,
+			AuthorizationList: m.AuthorizationList,
 		},
 		From:      m.To,
 		Signature: m.AuthData,
@@ -532,7 +771,7 @@ func (m ExternalTransaction) ToTransaction() *Transaction {
 }
 
 func (m *Transaction) SigningHash() (common.Hash, error) {
-	return common.Keccak(&m.TransactionDigest)
+	return typedHash(m.EnvelopeType(), &m.TransactionDigest)
 }
 
 func (m *ExternalTransaction) Sign(key *ecdsa.PrivateKey) error {
@@ -541,12 +780,12 @@ func (m *ExternalTransaction) Sign(key *ecdsa.PrivateKey) error {
 		return err
 	}
 
-	sig, err := crypto.Sign(hash.Bytes(), key)
+	auth, err := NewSecp256k1Signer(key).Sign(hash)
 	if err != nil {
 		return err
 	}
 
-	m.AuthData = hexutil.Bytes(sig)
+	m.AuthData = hexutil.Bytes(auth)
 
 	return nil
 }
@@ -567,6 +806,10 @@ func TransactionFlagsFromKind(internal bool, kind TransactionKind) TransactionFl
 		flags = append(flags, TransactionFlagRefund)
 	case ResponseTransactionKind:
 		flags = append(flags, TransactionFlagResponse)
+	case BlobTransactionKind:
+		flags = append(flags, TransactionFlagBlob)
+	case SetCodeTransactionKind:
+		flags = append(flags, TransactionFlagSetCode)
 	case ExecutionTransactionKind: // do nothing
 	}
 	return NewTransactionFlags(flags...)
@@ -591,6 +834,12 @@ func (m TransactionFlags) String() string {
 	if m.IsResponse() {
 		res += ", Response"
 	}
+	if m.IsBlob() {
+		res += ", Blob"
+	}
+	if m.IsSetCode() {
+		res += ", SetCode"
+	}
 	return res
 }
 
@@ -613,6 +862,12 @@ func (m TransactionFlags) MarshalJSON() ([]byte, error) {
 	if m.IsResponse() {
 		res += ", \"Response\""
 	}
+	if m.IsBlob() {
+		res += ", \"Blob\""
+	}
+	if m.IsSetCode() {
+		res += ", \"SetCode\""
+	}
 	return []byte(fmt.Sprintf("[%s]", res)), nil
 }
 
@@ -634,6 +889,10 @@ func (m *TransactionFlags) UnmarshalJSON(data []byte) error {
 			m.SetBit(TransactionFlagBounce)
 		case "Response":
 			m.SetBit(TransactionFlagResponse)
+		case "Blob":
+			m.SetBit(TransactionFlagBlob)
+		case "SetCode":
+			m.SetBit(TransactionFlagSetCode)
 		}
 	}
 	return nil
@@ -659,6 +918,14 @@ func (m TransactionFlags) IsResponse() bool {
 	return m.GetBit(TransactionFlagResponse)
 }
 
+func (m TransactionFlags) IsBlob() bool {
+	return m.GetBit(TransactionFlagBlob)
+}
+
+func (m TransactionFlags) IsSetCode() bool {
+	return m.GetBit(TransactionFlagSetCode)
+}
+
 type TxnWithHash struct {
 	*Transaction
 	hash common.Hash
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NilFoundation/nil/nil/common"
+)
+
+func TestTransactionMarshalBinaryLegacyRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tx := NewEmptyTransaction()
+	tx.ChainId = ChainId(1)
+	tx.Seqno = Seqno(5)
+
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	if data[0] < legacyRLPListMinByte {
+		t.Fatalf("expected a legacy RLP list byte (>= %#x), got %#x", legacyRLPListMinByte, data[0])
+	}
+
+	var got Transaction
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if got.ChainId != tx.ChainId || got.Seqno != tx.Seqno {
+		t.Fatalf("round trip mismatch: got %+v, want ChainId=%v Seqno=%v", got, tx.ChainId, tx.Seqno)
+	}
+}
+
+func TestTransactionMarshalBinaryTypedRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tx := NewEmptyTransaction()
+	tx.Flags.SetBit(TransactionFlagBlob)
+	tx.ChainId = ChainId(7)
+	tx.MaxFeePerBlobGas = GasToValue(1)
+	tx.BlobHashes = []common.Hash{common.BytesToHash([]byte{1, 2, 3})}
+
+	if typ := tx.EnvelopeType(); typ != TransactionType(0x03) {
+		t.Fatalf("expected blob transactions to use envelope type 0x03, got %#x", byte(typ))
+	}
+
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+	if data[0] != 0x03 {
+		t.Fatalf("expected a leading type byte of 0x03, got %#x", data[0])
+	}
+
+	var got Transaction
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+	if !got.IsBlob() {
+		t.Fatal("expected the decoded transaction to still be a blob transaction")
+	}
+	if got.ChainId != tx.ChainId {
+		t.Fatalf("chain id mismatch: got %v, want %v", got.ChainId, tx.ChainId)
+	}
+	if !reflect.DeepEqual(got.BlobHashes, tx.BlobHashes) {
+		t.Fatalf("blob hashes mismatch: got %v, want %v", got.BlobHashes, tx.BlobHashes)
+	}
+}
+
+func TestTransactionUnmarshalBinaryUnknownType(t *testing.T) {
+	t.Parallel()
+
+	var tx Transaction
+	if err := tx.UnmarshalBinary([]byte{0x02}); err == nil {
+		t.Fatal("expected an error for an unregistered transaction type")
+	}
+}
+
+func TestTransactionUnmarshalBinaryEmpty(t *testing.T) {
+	t.Parallel()
+
+	var tx Transaction
+	if err := tx.UnmarshalBinary(nil); err == nil {
+		t.Fatal("expected an error for empty input")
+	}
+}